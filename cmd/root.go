@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -51,7 +52,9 @@ func initConfig() {
 		viper.SetConfigName(".console-for-sap")
 	}
 
-	viper.AutomaticEnv() // read in environment variables that match
+	viper.SetEnvPrefix("console_for_sap")
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	viper.AutomaticEnv() // read in environment variables that match, e.g. CONSOLE_FOR_SAP_PORT
 
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err == nil {
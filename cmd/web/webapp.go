@@ -1,19 +1,23 @@
 package web
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/SUSE/console-for-sap-applications/web"
 )
 
-var host string
-var port int
-
 func NewWebappCmd() *cobra.Command {
 	webappCmd := &cobra.Command{
 		Use:   "webapp",
@@ -26,8 +30,17 @@ func NewWebappCmd() *cobra.Command {
 		Run:   serve,
 	}
 
-	serveCmd.Flags().StringVar(&host, "host", "0.0.0.0", "The host to bind the HTTP service to")
-	serveCmd.Flags().IntVarP(&port, "port", "p", 8080, "The port for the HTTP service to listen at")
+	serveCmd.Flags().String("host", "0.0.0.0", "The host to bind the HTTP service to")
+	serveCmd.Flags().IntP("port", "p", 8080, "The port for the HTTP service to listen at")
+	serveCmd.Flags().StringToString("security-header", nil, "Override a security response header, e.g. X-Frame-Options=SAMEORIGIN; an empty value removes it")
+	serveCmd.Flags().StringSlice("allow-ip", nil, "Only accept requests from these IP addresses or CIDR networks (default: allow all)")
+	serveCmd.Flags().String("log-format", web.LogFormatText, "The request log format, either text or json")
+	serveCmd.Flags().Int64("max-body-size", 1<<20, "The maximum size in bytes of request bodies, 0 for no limit")
+	serveCmd.Flags().Bool("read-only", false, "Disable every state-changing endpoint and UI action")
+	serveCmd.Flags().Duration("shutdown-timeout", 10*time.Second, "How long to wait for in-flight requests when stopping")
+
+	// every flag can also be set in the config file or as a CONSOLE_FOR_SAP_* environment variable
+	cobra.CheckErr(viper.BindPFlags(serveCmd.Flags()))
 
 	webappCmd.AddCommand(serveCmd)
 
@@ -35,15 +48,73 @@ func NewWebappCmd() *cobra.Command {
 }
 
 func serve(cmd *cobra.Command, args []string) {
-	engine := web.NewEngine()
+	networks, err := parseNetworks(viper.GetStringSlice("allow-ip"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	logFormat := viper.GetString("log-format")
+	if logFormat != web.LogFormatText && logFormat != web.LogFormatJSON {
+		log.Fatalf("unknown log format: %s", logFormat)
+	}
+
+	engine := web.NewEngine(web.Config{
+		SecurityHeaders: viper.GetStringMapString("security-header"),
+		AllowedNetworks: networks,
+		LogFormat:       logFormat,
+		ReadOnly:        viper.GetBool("read-only"),
+		MaxBodySize:     viper.GetInt64("max-body-size"),
+	})
 
 	s := &http.Server{
-		Addr:           fmt.Sprintf("%s:%d", host, port),
+		Addr:           fmt.Sprintf("%s:%d", viper.GetString("host"), viper.GetInt("port")),
 		Handler:        engine,
 		ReadTimeout:    10 * time.Second,
 		WriteTimeout:   10 * time.Second,
 		MaxHeaderBytes: 1 << 20,
 	}
 
-	log.Fatal(s.ListenAndServe())
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		if err := s.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal(err)
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("shutdown-timeout"))
+	defer cancel()
+	if err := s.Shutdown(shutdownCtx); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parseNetworks accepts both CIDR notations and bare IP addresses, the latter being single host networks
+func parseNetworks(values []string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, value := range values {
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP address: %s", value)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
 }
@@ -0,0 +1,35 @@
+package web
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseNetworks(t *testing.T) {
+	tests := []struct {
+		value   string
+		network string
+		wantErr bool
+	}{
+		{value: "10.0.0.1", network: "10.0.0.1/32"},
+		{value: "::1", network: "::1/128"},
+		{value: "10.0.0.0/8", network: "10.0.0.0/8"},
+		{value: "bogus", wantErr: true},
+		{value: "10.0.0.0/33", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			networks, err := parseNetworks([]string{tt.value})
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, networks)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, networks, 1)
+			assert.Equal(t, tt.network, networks[0].String())
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiVersion describes a version of the REST API, which is served under /api/<Name>
+type apiVersion struct {
+	Name string `json:"name"`
+	// Sunset is set when the version gets deprecated and tells when it is going to be removed
+	Sunset *time.Time `json:"sunset,omitempty"`
+}
+
+// apiVersions lists the supported API versions from the oldest to the current one.
+//
+// Versions are negotiated by path only. Breaking changes to a payload always land in a new version;
+// the previous one is then given a Sunset date and keeps being served, with deprecation headers
+// pointing clients to its successor, until that date has passed.
+var apiVersions = []apiVersion{
+	{Name: "v1"},
+}
+
+func (v apiVersion) deprecated() bool {
+	return v.Sunset != nil
+}
+
+func currentAPIVersion() apiVersion {
+	return apiVersions[len(apiVersions)-1]
+}
+
+// newAPIGroup registers the router group of an API version, whose root describes the version itself
+func newAPIGroup(api *gin.RouterGroup, version apiVersion) *gin.RouterGroup {
+	group := api.Group("/"+version.Name, apiVersionMiddleware(version))
+	group.GET("", func(c *gin.Context) {
+		c.JSON(http.StatusOK, version)
+	})
+	return group
+}
+
+// apiVersionMiddleware tags responses with the API version and, for deprecated versions,
+// adds the Deprecation, Sunset and successor Link headers
+func apiVersionMiddleware(version apiVersion) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("API-Version", version.Name)
+		if version.deprecated() {
+			c.Header("Deprecation", "true")
+			c.Header("Sunset", version.Sunset.UTC().Format(http.TimeFormat))
+			c.Header("Link", fmt.Sprintf("</api/%s>; rel=\"successor-version\"", currentAPIVersion().Name))
+		}
+		c.Next()
+	}
+}
+
+// apiVersionsHandler lets clients discover which API versions are available
+func apiVersionsHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"current":  currentAPIVersion().Name,
+		"versions": apiVersions,
+	})
+}
+
+// isAPIRequest tells whether the request targets the REST API rather than the HTML pages
+func isAPIRequest(c *gin.Context) bool {
+	path := c.Request.URL.Path
+	return path == "/api" || strings.HasPrefix(path, "/api/")
+}
+
+// notFoundHandler also tells API clients which versions are supported, so that requests to an unknown
+// or removed version can be told apart from plain typos
+func notFoundHandler(c *gin.Context) {
+	err := newNotFoundError("the requested page does not exist")
+	if isAPIRequest(c) {
+		var versions []string
+		for _, version := range apiVersions {
+			versions = append(versions, version.Name)
+		}
+		err.Detail = "resource not found"
+		err.Hint = "Check the resource path and the API version; GET /api lists the supported versions."
+		err.Extensions = gin.H{"versions": versions}
+	}
+	_ = c.Error(err)
+}
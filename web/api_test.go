@@ -0,0 +1,84 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_apiVersionsHandler(t *testing.T) {
+	engine := NewEngine(Config{})
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api", nil)
+	engine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `{"current":"v1","versions":[{"name":"v1"}]}`, resp.Body.String())
+}
+
+func Benchmark_apiVersionsHandler(b *testing.B) {
+	engine := newBenchmarkEngine()
+	req, _ := http.NewRequest("GET", "/api", nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		engine.ServeHTTP(httptest.NewRecorder(), req)
+	}
+}
+
+func Test_apiVersionGroup(t *testing.T) {
+	engine := NewEngine(Config{})
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1", nil)
+	engine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.Equal(t, "v1", resp.Header().Get("API-Version"))
+	assert.Empty(t, resp.Header().Get("Deprecation"))
+}
+
+func Test_apiVersionMiddleware_deprecated(t *testing.T) {
+	sunset := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	engine := gin.New()
+	engine.GET("/api/v0", apiVersionMiddleware(apiVersion{Name: "v0", Sunset: &sunset}), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v0", nil)
+	engine.ServeHTTP(resp, req)
+
+	assert.Equal(t, "v0", resp.Header().Get("API-Version"))
+	assert.Equal(t, "true", resp.Header().Get("Deprecation"))
+	assert.Equal(t, "Sat, 01 Jan 2022 00:00:00 GMT", resp.Header().Get("Sunset"))
+	assert.Equal(t, `</api/v1>; rel="successor-version"`, resp.Header().Get("Link"))
+}
+
+func Test_notFoundHandler_unknownAPIVersion(t *testing.T) {
+	engine := NewEngine(Config{})
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v9/hosts", nil)
+	engine.ServeHTTP(resp, req)
+
+	var body map[string]interface{}
+	_ = json.Unmarshal(resp.Body.Bytes(), &body)
+
+	assert.Equal(t, 404, resp.Code)
+	assert.Equal(t, "application/problem+json", resp.Header().Get("Content-Type"))
+	assert.Equal(t, "urn:console-for-sap:problem:not-found", body["type"])
+	assert.Equal(t, "not-found", body["code"])
+	assert.Equal(t, 404.0, body["status"])
+	assert.Equal(t, "resource not found", body["detail"])
+	assert.Equal(t, "/api/v9/hosts", body["instance"])
+	assert.Equal(t, []interface{}{"v1"}, body["versions"])
+	assert.Equal(t, resp.Header().Get("X-Request-ID"), body["request_id"])
+}
@@ -0,0 +1,12 @@
+package web
+
+import (
+	"io"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newBenchmarkEngine builds an engine which doesn't log requests, so that the output doesn't skew the figures
+func newBenchmarkEngine() *gin.Engine {
+	return NewEngine(Config{LogOutput: io.Discard})
+}
@@ -0,0 +1,64 @@
+package web
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newBodyLimitMiddleware caps the size of request bodies: requests announcing a bigger body are rejected
+// right away, the others can't read past the limit even if they lied about their length or sent it chunked
+func newBodyLimitMiddleware(maxSize int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > maxSize {
+			_ = c.Error(newPayloadTooLargeError(maxSize))
+			c.Abort()
+			return
+		}
+		// missing bodies stay as they are, so that the usual nil checks still catch them
+		if c.Request.Body != nil && c.Request.Body != http.NoBody {
+			source := &countingBody{ReadCloser: c.Request.Body}
+			c.Request.Body = &limitedBody{
+				ReadCloser: http.MaxBytesReader(c.Writer, source, maxSize),
+				writer:     c.Writer,
+				source:     source,
+				maxSize:    maxSize,
+			}
+		}
+		c.Next()
+	}
+}
+
+// countingBody counts the bytes read from the original request body
+type countingBody struct {
+	io.ReadCloser
+	read int64
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+	return n, err
+}
+
+// limitedBody turns the error of http.MaxBytesReader into a payload too large error, so that handlers
+// passing the read error on to c.Error get a 413 rather than an internal error
+type limitedBody struct {
+	io.ReadCloser
+	writer  http.ResponseWriter
+	source  *countingBody
+	maxSize int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	// MaxBytesReader only pulls more than maxSize bytes from the body when it went past the limit;
+	// its error can't be told apart otherwise before Go 1.19
+	if err != nil && b.source.read > b.maxSize {
+		// gin's writer hides the hook MaxBytesReader uses to have net/http close the connection
+		b.writer.Header().Set("Connection", "close")
+		err = newPayloadTooLargeError(b.maxSize)
+	}
+	return n, err
+}
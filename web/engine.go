@@ -2,6 +2,8 @@ package web
 
 import (
 	"embed"
+	"io"
+	"net"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -18,13 +20,66 @@ var layoutData = gin.H{
 	"copyright": "© 2019-2020 SUSE, all rights reserved.",
 }
 
-func NewEngine() *gin.Engine {
+// Config holds the tunables of the web engine; the zero value is a working default.
+type Config struct {
+	// SecurityHeaders overrides the default security response headers; an empty value drops the header
+	SecurityHeaders map[string]string
+	// AllowedNetworks restricts access to clients from these networks; empty means no restriction
+	AllowedNetworks []*net.IPNet
+	// LogFormat is the request log format, either LogFormatText or LogFormatJSON
+	LogFormat string
+	// LogOutput is where request logs are written; nil means gin's default writer
+	LogOutput io.Writer
+	// ReadOnly rejects all state-changing requests and tells the UI to hide its actions
+	ReadOnly bool
+	// MaxBodySize is the maximum size in bytes of request bodies; zero means no limit
+	MaxBodySize int64
+	// Components are the server subsystems reported by the health API and the readiness probe
+	Components []Component
+}
+
+// newLayoutData adds the engine settings the templates need to the static layout data
+func newLayoutData(config Config) gin.H {
+	data := gin.H{"readOnly": config.ReadOnly}
+	for key, value := range layoutData {
+		data[key] = value
+	}
+	return data
+}
+
+func NewEngine(config Config) *gin.Engine {
 
-	engine := gin.Default()
-	engine.HTMLRender = NewLayoutRender(templatesFS, layoutData, "templates/*.tmpl")
+	engine := gin.New()
+	// the access log must show the peer the allowlist checked, not an address the client claims to forward for
+	engine.ForwardedByClientIP = false
+	engine.Use(newLoggerMiddleware(config.LogFormat, config.LogOutput), requestIDMiddleware, errorHandlerMiddleware, recoveryMiddleware)
+	engine.HTMLRender = NewLayoutRender(templatesFS, newLayoutData(config), "templates/*.tmpl")
+
+	engine.Use(securityHeadersMiddleware(config.SecurityHeaders))
+
+	// probes are registered before the allowlist so that orchestrators can always reach them
+	engine.GET("/healthz", healthzHandler)
+	engine.GET("/readyz", newReadyzHandler(config.Components))
+
+	// the allowlist goes first, so that rejected clients learn nothing about the server settings
+	if len(config.AllowedNetworks) > 0 {
+		engine.Use(ipAllowlistMiddleware(config.AllowedNetworks))
+	}
+	if config.ReadOnly {
+		engine.Use(readOnlyMiddleware)
+	}
+	if config.MaxBodySize > 0 {
+		engine.Use(newBodyLimitMiddleware(config.MaxBodySize))
+	}
 
 	engine.StaticFS("/static", http.FS(assetsFS))
 	engine.GET("/", homeHandler)
+	engine.NoRoute(notFoundHandler)
+
+	apiGroup := engine.Group("/api")
+	apiGroup.GET("", apiVersionsHandler)
+	apiV1 := newAPIGroup(apiGroup, apiVersions[0])
+	apiV1.GET("/health", newComponentsHealthHandler(config.Components))
 
 	return engine
 }
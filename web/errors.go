@@ -0,0 +1,137 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// problemTypePrefix namespaces the stable error codes in the type member of API problem details
+const problemTypePrefix = "urn:console-for-sap:problem:"
+
+// httpError is an error that knows how it should be presented to the user: the status to answer with,
+// what went wrong and a hint at how to remedy it
+type httpError struct {
+	Status int
+	// Code identifies the kind of error for API clients and must never change once released
+	Code   string
+	Title  string
+	Detail string
+	Hint   string
+	// Extensions are additional members of API error bodies
+	Extensions gin.H
+	Err        error
+}
+
+func (e *httpError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return e.Detail
+}
+
+func (e *httpError) Unwrap() error {
+	return e.Err
+}
+
+func newNotFoundError(detail string) *httpError {
+	return &httpError{
+		Status: http.StatusNotFound,
+		Code:   "not-found",
+		Title:  "Not Found",
+		Detail: detail,
+		Hint:   "Check the address for typos, or start again from the home page.",
+	}
+}
+
+func newReadOnlyError() *httpError {
+	return &httpError{
+		Status: http.StatusForbidden,
+		Code:   "read-only",
+		Title:  "Read-only Mode",
+		Detail: "the server is running in read-only mode",
+		Hint:   "Changes are disabled while the console runs with --read-only; ask your administrator to lift it.",
+	}
+}
+
+func newForbiddenAddressError() *httpError {
+	return &httpError{
+		Status: http.StatusForbidden,
+		Code:   "address-not-allowed",
+		Title:  "Forbidden",
+		Detail: "your network address is not allowed to access the console",
+		Hint:   "Connect from an allowed network, or ask your administrator to add your address with --allow-ip.",
+	}
+}
+
+func newPayloadTooLargeError(maxSize int64) *httpError {
+	return &httpError{
+		Status:     http.StatusRequestEntityTooLarge,
+		Code:       "payload-too-large",
+		Title:      "Payload Too Large",
+		Detail:     fmt.Sprintf("the request body exceeds the maximum size of %d bytes", maxSize),
+		Hint:       "Send a smaller request, or ask your administrator to raise --max-body-size.",
+		Extensions: gin.H{"max_size": maxSize},
+	}
+}
+
+// newInternalError wraps errors that handlers didn't classify themselves
+func newInternalError(err error) *httpError {
+	return &httpError{
+		Status: http.StatusInternalServerError,
+		Code:   "internal-error",
+		Title:  "Internal Server Error",
+		Detail: "the server failed to process the request",
+		Hint:   "Try again later; if the problem persists, report it along with the request ID.",
+		Err:    err,
+	}
+}
+
+// errorHandlerMiddleware renders the last error that handlers attached with c.Error,
+// unless they already wrote a response of their own
+func errorHandlerMiddleware(c *gin.Context) {
+	c.Next()
+
+	if len(c.Errors) == 0 || c.Writer.Written() {
+		return
+	}
+
+	var httpErr *httpError
+	if err := c.Errors.Last().Err; !errors.As(err, &httpErr) {
+		httpErr = newInternalError(err)
+	}
+	renderError(c, httpErr)
+}
+
+// renderError answers with an error page, or with RFC 7807 problem details for API requests
+func renderError(c *gin.Context, err *httpError) {
+	if isAPIRequest(c) {
+		problem := gin.H{
+			"type":       problemTypePrefix + err.Code,
+			"title":      err.Title,
+			"status":     err.Status,
+			"detail":     err.Detail,
+			"instance":   c.Request.URL.Path,
+			"code":       err.Code,
+			"hint":       err.Hint,
+			"request_id": requestID(c),
+		}
+		for key, value := range err.Extensions {
+			problem[key] = value
+		}
+		// gin's JSON renderer keeps the content type if it's already set
+		c.Header("Content-Type", "application/problem+json")
+		c.JSON(err.Status, problem)
+		return
+	}
+
+	c.HTML(err.Status, "error.html.tmpl", gin.H{
+		"status":    err.Status,
+		"message":   err.Title,
+		"detail":    err.Detail,
+		"hint":      err.Hint,
+		"requestID": requestID(c),
+	})
+}
@@ -0,0 +1,115 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_errorHandlerMiddleware_notFoundPage(t *testing.T) {
+	engine := NewEngine(Config{})
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/does-not-exist", nil)
+	req.Header.Set("X-Request-ID", "my-request")
+	engine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+	assert.Equal(t, "my-request", resp.Header().Get("X-Request-ID"))
+	assert.Contains(t, resp.Body.String(), "404 Not Found")
+	assert.Contains(t, resp.Body.String(), "the requested page does not exist")
+	assert.Contains(t, resp.Body.String(), "my-request")
+}
+
+func Test_errorHandlerMiddleware_nestedNotFoundPage(t *testing.T) {
+	engine := NewEngine(Config{})
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/a/b", nil)
+	engine.ServeHTTP(resp, req)
+
+	// assets must not resolve against the missing page's path, or they would be 404 pages themselves
+	assert.Equal(t, 404, resp.Code)
+	assert.Contains(t, resp.Body.String(), `href="/static/frontend/assets/stylesheets/stylesheets.css"`)
+	assert.NotContains(t, resp.Body.String(), `"static/`)
+}
+
+func Test_recoveryMiddleware_errorPage(t *testing.T) {
+	engine := NewEngine(Config{})
+	engine.GET("/panicking", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/panicking", nil)
+	req.Header.Set("X-Request-ID", "my-request")
+	engine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 500, resp.Code)
+	assert.Contains(t, resp.Body.String(), "500 Internal Server Error")
+	assert.Contains(t, resp.Body.String(), "my-request")
+	assert.NotContains(t, resp.Body.String(), "boom")
+}
+
+func Test_recoveryMiddleware_problem(t *testing.T) {
+	engine := NewEngine(Config{})
+	engine.GET("/api/v1/panicking", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/panicking", nil)
+	req.Header.Set("X-Request-ID", "my-request")
+	engine.ServeHTTP(resp, req)
+
+	var body map[string]interface{}
+	_ = json.Unmarshal(resp.Body.Bytes(), &body)
+
+	assert.Equal(t, 500, resp.Code)
+	assert.Equal(t, "application/problem+json", resp.Header().Get("Content-Type"))
+	assert.Equal(t, "internal-error", body["code"])
+	assert.Equal(t, "my-request", body["request_id"])
+	assert.NotContains(t, resp.Body.String(), "boom")
+}
+
+func Test_errorHandlerMiddleware_unclassifiedError(t *testing.T) {
+	engine := gin.New()
+	engine.Use(requestIDMiddleware, errorHandlerMiddleware)
+	engine.GET("/api/v1/failing", func(c *gin.Context) {
+		_ = c.Error(errors.New("connection refused"))
+	})
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/failing", nil)
+	engine.ServeHTTP(resp, req)
+
+	var body map[string]interface{}
+	_ = json.Unmarshal(resp.Body.Bytes(), &body)
+
+	assert.Equal(t, 500, resp.Code)
+	assert.Equal(t, "application/problem+json", resp.Header().Get("Content-Type"))
+	assert.Equal(t, "internal-error", body["code"])
+	assert.Equal(t, "the server failed to process the request", body["detail"])
+	assert.NotContains(t, resp.Body.String(), "connection refused")
+}
+
+func Test_errorHandlerMiddleware_handlerResponseWins(t *testing.T) {
+	engine := gin.New()
+	engine.Use(errorHandlerMiddleware)
+	engine.GET("/api/v1/handled", func(c *gin.Context) {
+		_ = c.Error(errors.New("already handled"))
+		c.String(http.StatusConflict, "conflict")
+	})
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/handled", nil)
+	engine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 409, resp.Code)
+	assert.Equal(t, "conflict", resp.Body.String())
+}
@@ -0,0 +1,73 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Component is a server subsystem able to report whether it works, e.g. a backend connection or a collector
+type Component interface {
+	Name() string
+	// Health returns the last error the component ran into, or nil if it is healthy
+	Health() error
+}
+
+const (
+	healthPassing = "passing"
+	healthFailing = "failing"
+)
+
+type componentHealth struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	LastError string `json:"last_error,omitempty"`
+}
+
+// checkComponents collects the health of every component; the overall status fails as soon as one of them does
+func checkComponents(components []Component) (string, []componentHealth) {
+	status := healthPassing
+	report := []componentHealth{}
+	for _, component := range components {
+		health := componentHealth{Name: component.Name(), Status: healthPassing}
+		if err := component.Health(); err != nil {
+			health.Status = healthFailing
+			health.LastError = err.Error()
+			status = healthFailing
+		}
+		report = append(report, health)
+	}
+	return status, report
+}
+
+func healthStatusCode(status string) int {
+	if status == healthFailing {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
+
+// healthzHandler is the liveness probe, it answers as long as the process is able to serve requests
+func healthzHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// newReadyzHandler returns the readiness probe, which fails while any component is unhealthy;
+// it deliberately leaves out the details, as probes are reachable from anywhere
+func newReadyzHandler(components []Component) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		status, _ := checkComponents(components)
+		c.JSON(healthStatusCode(status), gin.H{"status": status})
+	}
+}
+
+// newComponentsHealthHandler returns the self-status API, reporting the state of each component
+func newComponentsHealthHandler(components []Component) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		status, report := checkComponents(components)
+		c.JSON(healthStatusCode(status), gin.H{
+			"status":     status,
+			"components": report,
+		})
+	}
+}
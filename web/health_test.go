@@ -0,0 +1,83 @@
+package web
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeComponent struct {
+	name string
+	err  error
+}
+
+func (f fakeComponent) Name() string {
+	return f.name
+}
+
+func (f fakeComponent) Health() error {
+	return f.err
+}
+
+func Test_probesBypassAllowlist(t *testing.T) {
+	_, network, _ := net.ParseCIDR("10.0.0.0/8")
+	engine := NewEngine(Config{AllowedNetworks: []*net.IPNet{network}})
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/healthz", nil)
+	req.RemoteAddr = "192.168.1.1:51000"
+	engine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/readyz", nil)
+	req.RemoteAddr = "192.168.1.1:51000"
+	engine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `{"status":"passing"}`, resp.Body.String())
+}
+
+func Test_componentsHealth(t *testing.T) {
+	engine := NewEngine(Config{Components: []Component{
+		fakeComponent{name: "consul"},
+		fakeComponent{name: "collector", err: errors.New("connection refused")},
+	}})
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/health", nil)
+	engine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 503, resp.Code)
+	assert.JSONEq(t, `{
+		"status": "failing",
+		"components": [
+			{"name": "consul", "status": "passing"},
+			{"name": "collector", "status": "failing", "last_error": "connection refused"}
+		]
+	}`, resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/readyz", nil)
+	engine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 503, resp.Code)
+	assert.JSONEq(t, `{"status":"failing"}`, resp.Body.String())
+}
+
+func Test_componentsHealth_none(t *testing.T) {
+	engine := NewEngine(Config{})
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/health", nil)
+	engine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `{"status":"passing","components":[]}`, resp.Body.String())
+}
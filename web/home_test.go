@@ -9,7 +9,7 @@ import (
 )
 
 func Test_homeHandler(t *testing.T) {
-	engine := NewEngine()
+	engine := NewEngine(Config{})
 
 	resp := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/", nil)
@@ -18,3 +18,14 @@ func Test_homeHandler(t *testing.T) {
 	assert.Equal(t, 200, resp.Code)
 	assert.Contains(t, resp.Body.String(), "This is the home page")
 }
+
+func Benchmark_homeHandler(b *testing.B) {
+	engine := newBenchmarkEngine()
+	req, _ := http.NewRequest("GET", "/", nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		engine.ServeHTTP(httptest.NewRecorder(), req)
+	}
+}
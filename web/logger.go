@@ -0,0 +1,72 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Request log formats supported by the engine
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// accessLogEntry is the shape of a request log line in the JSON log format
+type accessLogEntry struct {
+	Time      string  `json:"time"`
+	Status    int     `json:"status"`
+	Latency   float64 `json:"latency_ms"`
+	ClientIP  string  `json:"client_ip"`
+	Method    string  `json:"method"`
+	Path      string  `json:"path"`
+	RequestID string  `json:"request_id,omitempty"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// newLoggerMiddleware returns the gin request logger for the given format, defaulting to text;
+// a nil output means gin's default writer
+func newLoggerMiddleware(format string, output io.Writer) gin.HandlerFunc {
+	config := gin.LoggerConfig{Output: output, Formatter: textLogFormatter}
+	if format == LogFormatJSON {
+		config.Formatter = jsonLogFormatter
+	}
+	return gin.LoggerWithConfig(config)
+}
+
+// textLogFormatter follows gin's default format, without colors, and appends the request ID
+// so that the one shown on error pages can be looked up in the logs
+func textLogFormatter(params gin.LogFormatterParams) string {
+	id, _ := params.Keys[requestIDKey].(string)
+	return fmt.Sprintf("[GIN] %v | %3d | %13v | %15s | %-7s %#v | %s\n%s",
+		params.TimeStamp.Format("2006/01/02 - 15:04:05"),
+		params.StatusCode,
+		params.Latency,
+		params.ClientIP,
+		params.Method,
+		params.Path,
+		id,
+		params.ErrorMessage,
+	)
+}
+
+// jsonLogFormatter writes one JSON object per line, so that log collectors don't need to parse free text
+func jsonLogFormatter(params gin.LogFormatterParams) string {
+	entry := accessLogEntry{
+		Time:     params.TimeStamp.Format(time.RFC3339),
+		Status:   params.StatusCode,
+		Latency:  float64(params.Latency) / float64(time.Millisecond),
+		ClientIP: params.ClientIP,
+		Method:   params.Method,
+		Path:     params.Path,
+		Error:    params.ErrorMessage,
+	}
+	if id, ok := params.Keys[requestIDKey].(string); ok {
+		entry.RequestID = id
+	}
+	line, _ := json.Marshal(entry)
+	return string(line) + "\n"
+}
@@ -0,0 +1,33 @@
+package web
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLogParams() gin.LogFormatterParams {
+	return gin.LogFormatterParams{
+		TimeStamp:  time.Date(2021, time.March, 22, 10, 0, 0, 0, time.UTC),
+		StatusCode: 200,
+		Latency:    1500 * time.Microsecond,
+		ClientIP:   "10.1.2.3",
+		Method:     "GET",
+		Path:       "/",
+		Keys:       map[string]interface{}{requestIDKey: "my-request"},
+	}
+}
+
+func Test_textLogFormatter(t *testing.T) {
+	line := textLogFormatter(newTestLogParams())
+
+	assert.Equal(t, `[GIN] 2021/03/22 - 10:00:00 | 200 |         1.5ms |        10.1.2.3 | GET     "/" | my-request`+"\n", line)
+}
+
+func Test_jsonLogFormatter(t *testing.T) {
+	line := jsonLogFormatter(newTestLogParams())
+
+	assert.Equal(t, `{"time":"2021-03-22T10:00:00Z","status":200,"latency_ms":1.5,"client_ip":"10.1.2.3","method":"GET","path":"/","request_id":"my-request"}`+"\n", line)
+}
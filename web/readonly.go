@@ -0,0 +1,19 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readOnlyMiddleware rejects every request that could change state, i.e. anything but GET, HEAD and OPTIONS
+func readOnlyMiddleware(c *gin.Context) {
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		c.Next()
+		return
+	}
+
+	_ = c.Error(newReadOnlyError())
+	c.Abort()
+}
@@ -0,0 +1,43 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_readOnlyMiddleware(t *testing.T) {
+	engine := NewEngine(Config{ReadOnly: true})
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	engine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.Contains(t, resp.Body.String(), "read-only mode")
+
+	resp = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/api/v1", nil)
+	engine.ServeHTTP(resp, req)
+
+	var body map[string]interface{}
+	_ = json.Unmarshal(resp.Body.Bytes(), &body)
+
+	assert.Equal(t, 403, resp.Code)
+	assert.Equal(t, "read-only", body["code"])
+	assert.Equal(t, "the server is running in read-only mode", body["detail"])
+}
+
+func Test_readOnlyBanner_disabled(t *testing.T) {
+	engine := NewEngine(Config{})
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	engine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.NotContains(t, resp.Body.String(), "read-only mode")
+}
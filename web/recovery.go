@@ -0,0 +1,31 @@
+package web
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+)
+
+// recoveryMiddleware turns panics into internal errors rendered like any other error, so that clients still
+// get an error page or a problem body with the request ID, where gin's own Recovery answers an empty 500
+func recoveryMiddleware(c *gin.Context) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		fmt.Fprintf(gin.DefaultErrorWriter, "[Recovery] panic recovered in request %s: %v\n%s\n", requestID(c), r, debug.Stack())
+
+		// abort, otherwise the middlewares up the chain would carry on with the handlers left
+		c.Abort()
+		if c.Writer.Written() {
+			return
+		}
+		err := newInternalError(fmt.Errorf("panic: %v", r))
+		_ = c.Error(err)
+		renderError(c, err)
+	}()
+	c.Next()
+}
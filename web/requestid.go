@@ -0,0 +1,43 @@
+package web
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	requestIDHeader = "X-Request-ID"
+	requestIDKey    = "requestID"
+)
+
+// validRequestID keeps client-provided IDs from injecting garbage into logs and pages
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// requestIDMiddleware tags every request with a correlation ID, reusing the one sent by the client or
+// a proxy in front of us if any, so that error reports can be matched with the server logs
+func requestIDMiddleware(c *gin.Context) {
+	id := c.GetHeader(requestIDHeader)
+	if !validRequestID.MatchString(id) {
+		id = newRequestID()
+	}
+	c.Set(requestIDKey, id)
+	c.Header(requestIDHeader, id)
+	c.Next()
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		// the system randomness source is broken, nothing sensible can be done about it
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// requestID returns the correlation ID of the current request
+func requestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
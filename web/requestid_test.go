@@ -0,0 +1,26 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_requestIDMiddleware(t *testing.T) {
+	engine := NewEngine(Config{})
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	engine.ServeHTTP(resp, req)
+
+	assert.Regexp(t, "^[0-9a-f]{32}$", resp.Header().Get("X-Request-ID"))
+
+	resp = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Request-ID", "<script>")
+	engine.ServeHTTP(resp, req)
+
+	assert.Regexp(t, "^[0-9a-f]{32}$", resp.Header().Get("X-Request-ID"))
+}
@@ -0,0 +1,62 @@
+package web
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultSecurityHeaders are sent with every response unless overridden
+var defaultSecurityHeaders = map[string]string{
+	"Content-Security-Policy":   "default-src 'self'; style-src 'self' 'unsafe-inline'; img-src 'self' data:; frame-ancestors 'none'",
+	"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
+	"X-Frame-Options":           "DENY",
+	"X-Content-Type-Options":    "nosniff",
+	"Referrer-Policy":           "same-origin",
+}
+
+// securityHeadersMiddleware sets the default security headers merged with the given overrides;
+// overriding a header with an empty value removes it altogether
+func securityHeadersMiddleware(overrides map[string]string) gin.HandlerFunc {
+	headers := map[string]string{}
+	for name, value := range defaultSecurityHeaders {
+		headers[name] = value
+	}
+	for name, value := range overrides {
+		name = http.CanonicalHeaderKey(name)
+		if value == "" {
+			delete(headers, name)
+			continue
+		}
+		headers[name] = value
+	}
+
+	return func(c *gin.Context) {
+		for name, value := range headers {
+			c.Header(name, value)
+		}
+		c.Next()
+	}
+}
+
+// ipAllowlistMiddleware rejects requests coming from clients outside the given networks;
+// the peer address is used rather than gin's ClientIP, which trusts forwarding headers from anyone
+func ipAllowlistMiddleware(networks []*net.IPNet) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		host, _, err := net.SplitHostPort(c.Request.RemoteAddr)
+		if err != nil {
+			host = c.Request.RemoteAddr
+		}
+		if ip := net.ParseIP(host); ip != nil {
+			for _, network := range networks {
+				if network.Contains(ip) {
+					c.Next()
+					return
+				}
+			}
+		}
+		_ = c.Error(newForbiddenAddressError())
+		c.Abort()
+	}
+}
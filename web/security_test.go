@@ -0,0 +1,72 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_securityHeadersMiddleware(t *testing.T) {
+	engine := NewEngine(Config{
+		SecurityHeaders: map[string]string{
+			"x-frame-options":           "SAMEORIGIN",
+			"Strict-Transport-Security": "",
+		},
+	})
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	engine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.Equal(t, "SAMEORIGIN", resp.Header().Get("X-Frame-Options"))
+	assert.Equal(t, "nosniff", resp.Header().Get("X-Content-Type-Options"))
+	assert.Contains(t, resp.Header().Get("Content-Security-Policy"), "default-src 'self'")
+	assert.Empty(t, resp.Header().Get("Strict-Transport-Security"))
+}
+
+func Test_ipAllowlistMiddleware(t *testing.T) {
+	_, network, _ := net.ParseCIDR("10.0.0.0/8")
+	var logs bytes.Buffer
+	engine := NewEngine(Config{AllowedNetworks: []*net.IPNet{network}, LogFormat: LogFormatJSON, LogOutput: &logs})
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	req.RemoteAddr = "10.1.2.3:51000"
+	engine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+
+	logs.Reset()
+	resp = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/", nil)
+	req.RemoteAddr = "192.168.1.1:51000"
+	req.Header.Set("X-Forwarded-For", "10.1.2.3")
+	engine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 403, resp.Code)
+	assert.Contains(t, logs.String(), `"client_ip":"192.168.1.1"`)
+}
+
+func Test_ipAllowlistMiddleware_beforeSettings(t *testing.T) {
+	_, network, _ := net.ParseCIDR("10.0.0.0/8")
+	engine := NewEngine(Config{AllowedNetworks: []*net.IPNet{network}, ReadOnly: true, MaxBodySize: 8})
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1", strings.NewReader("0123456789"))
+	req.RemoteAddr = "192.168.1.1:51000"
+	engine.ServeHTTP(resp, req)
+
+	var body map[string]interface{}
+	_ = json.Unmarshal(resp.Body.Bytes(), &body)
+
+	assert.Equal(t, 403, resp.Code)
+	assert.Equal(t, "address-not-allowed", body["code"])
+	assert.NotContains(t, body, "max_size")
+}